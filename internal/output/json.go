@@ -98,6 +98,34 @@ func ToWarningsJSON(r model.Result) (string, error) {
 	return string(data), nil
 }
 
+// ToActionJSON reports the outcome of a control action (suspend/resume/
+// signal) requested via --action, following the same flat shape as
+// ToWarningsJSON so scripts can chain witr's inspection with remediation
+// without parsing two different response formats.
+func ToActionJSON(pid int, action string, actionErr error) (string, error) {
+	type actionResult struct {
+		PID     int
+		Action  string
+		Success bool
+		Error   string `json:",omitempty"`
+	}
+
+	res := actionResult{
+		PID:     pid,
+		Action:  action,
+		Success: actionErr == nil,
+	}
+	if actionErr != nil {
+		res.Error = actionErr.Error()
+	}
+
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 func ToEnvJSON(r model.Result) (string, error) {
 	type envResult struct {
 		PID     int
@@ -0,0 +1,95 @@
+//go:build linux
+
+package container
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// scopeIDRe matches the container ID embedded in a systemd-managed
+	// scope unit, e.g. "cri-containerd-<id>.scope", "docker-<id>.scope",
+	// or "crio-<id>.scope".
+	scopeIDRe = regexp.MustCompile(`(?:cri-containerd|docker|crio)-([0-9a-f]{12,64})\.scope$`)
+
+	// cgroupfsIDRe matches the trailing container ID under the legacy
+	// (non-systemd) cgroupfs driver layout, e.g. "/docker/<id>" or
+	// ".../kubepods/besteffort/pod<uid>/<id>".
+	cgroupfsIDRe = regexp.MustCompile(`/([0-9a-f]{64})$`)
+
+	// podUIDRe pulls the pod UID out of either driver's naming
+	// convention: "pod<uid-with-dashes>" (cgroupfs) or
+	// "kubepods-...-pod<uid_with_underscores>.slice" (systemd).
+	podUIDRe = regexp.MustCompile(`pod([0-9a-f]{8}[_-][0-9a-f]{4}[_-][0-9a-f]{4}[_-][0-9a-f]{4}[_-][0-9a-f]{12})`)
+)
+
+// Detect parses /proc/<pid>/cgroup, matching both the v1 (multiple
+// hierarchies, one per line) and v2 (single unified hierarchy, "0::path")
+// formats, and resolves the container ID it finds to a name via the local
+// container runtime.
+func Detect(pid int) (Info, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return Info{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := parts[2]
+
+		id, runtime := parseContainerID(path)
+		if id == "" {
+			continue
+		}
+
+		info := Info{Runtime: runtime, ContainerID: id}
+		name, podName, podNamespace := resolveContainerMetadata(runtime, id)
+		info.ContainerName = name
+		if podUIDRe.MatchString(path) {
+			info.PodName, info.PodNamespace = podName, podNamespace
+		}
+		return info, nil
+	}
+
+	return Info{}, scanner.Err()
+}
+
+// parseContainerID extracts a container ID and best-guess runtime name
+// from a single cgroup path, trying the systemd scope-unit naming first
+// and falling back to the legacy cgroupfs driver's bare-ID path segment.
+func parseContainerID(path string) (id, runtime string) {
+	if m := scopeIDRe.FindStringSubmatch(path); m != nil {
+		id = m[1]
+		switch {
+		case strings.Contains(path, "cri-containerd-"):
+			runtime = "containerd"
+		case strings.Contains(path, "crio-"):
+			runtime = "cri-o"
+		default:
+			runtime = "docker"
+		}
+		return id, runtime
+	}
+
+	if m := cgroupfsIDRe.FindStringSubmatch(path); m != nil {
+		id = m[1]
+		if strings.Contains(path, "docker") {
+			runtime = "docker"
+		} else {
+			runtime = "containerd"
+		}
+		return id, runtime
+	}
+
+	return "", ""
+}
@@ -0,0 +1,63 @@
+//go:build linux
+
+package container
+
+import "testing"
+
+func TestParseContainerID(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		wantID      string
+		wantRuntime string
+	}{
+		{
+			name:        "systemd containerd scope",
+			path:        "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice/cri-containerd-abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789.scope",
+			wantID:      "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789",
+			wantRuntime: "containerd",
+		},
+		{
+			name:        "systemd docker scope",
+			path:        "/system.slice/docker-abcdef012345678901234567890123456789012345678901234567890123.scope",
+			wantID:      "abcdef012345678901234567890123456789012345678901234567890123",
+			wantRuntime: "docker",
+		},
+		{
+			name:        "systemd crio scope",
+			path:        "/kubepods.slice/crio-abcdef012345678901234567890123456789012345678901234567890123.scope",
+			wantID:      "abcdef012345678901234567890123456789012345678901234567890123",
+			wantRuntime: "cri-o",
+		},
+		{
+			name:        "legacy cgroupfs docker",
+			path:        "/docker/abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789",
+			wantID:      "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789",
+			wantRuntime: "docker",
+		},
+		{
+			name:        "legacy cgroupfs kubepods (non-docker defaults to containerd)",
+			path:        "/kubepods/besteffort/pod1234/abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789",
+			wantID:      "abcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789",
+			wantRuntime: "containerd",
+		},
+		{
+			name: "not a container cgroup",
+			path: "/user.slice/user-1000.slice",
+		},
+		{
+			name: "empty path",
+			path: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, runtime := parseContainerID(tt.path)
+			if id != tt.wantID || runtime != tt.wantRuntime {
+				t.Errorf("parseContainerID(%q) = (%q, %q), want (%q, %q)",
+					tt.path, id, runtime, tt.wantID, tt.wantRuntime)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Package container identifies which container — and, if applicable,
+// which Kubernetes pod — a process belongs to by parsing its cgroup
+// membership rather than guessing from its command line.
+package container
+
+// Info describes the container (and pod, if any) a process is running
+// inside. The zero value means "not containerized".
+type Info struct {
+	Runtime       string // "docker", "containerd", "cri-o"
+	ContainerID   string
+	ContainerName string
+	PodName       string
+	PodNamespace  string
+}
@@ -0,0 +1,122 @@
+//go:build linux || darwin
+
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+const dialTimeout = 500 * time.Millisecond
+
+// criSockets lists the well-known CRI runtime sockets we probe, in the
+// order they're tried.
+var criSockets = []string{
+	"/run/containerd/containerd.sock",
+	"/var/run/crio/crio.sock",
+}
+
+const dockerSocket = "/var/run/docker.sock"
+
+// resolveContainerMetadata turns a short container ID into a human-readable
+// name plus, if any, its Kubernetes pod name/namespace, in a single lookup.
+// For CRI runtimes (containerd, cri-o) it calls RuntimeService.
+// ListContainers directly over gRPC, since the CRI is gRPC-only and
+// reaching it this way avoids depending on the crictl binary being
+// installed. Docker predates CRI and exposes its own HTTP API instead, so
+// it's queried separately over its UNIX socket and never carries pod
+// metadata.
+func resolveContainerMetadata(runtime, id string) (name, podName, podNamespace string) {
+	if runtime == "docker" {
+		return dockerContainerName(id), "", ""
+	}
+
+	c, ok := criInspect(id)
+	if !ok {
+		return "", "", ""
+	}
+	return c.Labels["io.kubernetes.container.name"], c.Labels["io.kubernetes.pod.name"], c.Labels["io.kubernetes.pod.namespace"]
+}
+
+// criInspect dials each known CRI socket in turn and runs
+// RuntimeService.ListContainers filtered to id, returning the first match.
+func criInspect(id string) (*runtimeapi.Container, bool) {
+	for _, sock := range criSockets {
+		if _, err := os.Stat(sock); err != nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		conn, err := grpc.DialContext(ctx, "unix://"+sock,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: dialTimeout}),
+		)
+		if err != nil {
+			cancel()
+			continue
+		}
+
+		client := runtimeapi.NewRuntimeServiceClient(conn)
+		resp, err := client.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+			Filter: &runtimeapi.ContainerFilter{Id: id},
+		})
+		conn.Close()
+		cancel()
+		if err != nil || len(resp.Containers) == 0 {
+			continue
+		}
+		return resp.Containers[0], true
+	}
+
+	return nil, false
+}
+
+// dockerContainerName queries the Docker Engine API's "/containers/json"
+// endpoint over the UNIX socket and matches on ID prefix, since that's
+// how the cgroup path truncates it.
+func dockerContainerName(id string) string {
+	if _, err := os.Stat(dockerSocket); err != nil {
+		return ""
+	}
+
+	client := &http.Client{
+		Timeout: 2 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				d := net.Dialer{Timeout: dialTimeout}
+				return d.DialContext(ctx, "unix", dockerSocket)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://unix/containers/json?all=true")
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	var containers []struct {
+		ID    string   `json:"Id"`
+		Names []string `json:"Names"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return ""
+	}
+
+	for _, c := range containers {
+		if strings.HasPrefix(c.ID, id) && len(c.Names) > 0 {
+			return strings.TrimPrefix(c.Names[0], "/")
+		}
+	}
+	return ""
+}
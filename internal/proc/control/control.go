@@ -0,0 +1,9 @@
+// Package control lets witr act on a process, not just inspect it:
+// suspend, resume, or send it a signal.
+package control
+
+import "errors"
+
+// ErrUnsupported is returned when the requested action has no reasonable
+// equivalent on the current platform.
+var ErrUnsupported = errors.New("control: action not supported on this platform")
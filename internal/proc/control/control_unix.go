@@ -0,0 +1,32 @@
+//go:build linux || darwin
+
+package control
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Suspend stops pid via SIGSTOP.
+func Suspend(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGSTOP); err != nil {
+		return fmt.Errorf("control: suspend pid %d: %w", pid, err)
+	}
+	return nil
+}
+
+// Resume continues a previously-suspended pid via SIGCONT.
+func Resume(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGCONT); err != nil {
+		return fmt.Errorf("control: resume pid %d: %w", pid, err)
+	}
+	return nil
+}
+
+// Signal delivers sig to pid.
+func Signal(pid int, sig syscall.Signal) error {
+	if err := syscall.Kill(pid, sig); err != nil {
+		return fmt.Errorf("control: signal pid %d with %v: %w", pid, sig, err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+//go:build windows
+
+package control
+
+import (
+	"fmt"
+	"syscall"
+)
+
+const processSuspendResume = 0x0800
+
+var (
+	modntdll             = syscall.NewLazyDLL("ntdll.dll")
+	procNtSuspendProcess = modntdll.NewProc("NtSuspendProcess")
+	procNtResumeProcess  = modntdll.NewProc("NtResumeProcess")
+
+	modkernel32                  = syscall.NewLazyDLL("kernel32.dll")
+	procGenerateConsoleCtrlEvent = modkernel32.NewProc("GenerateConsoleCtrlEvent")
+	procAttachConsole            = modkernel32.NewProc("AttachConsole")
+	procFreeConsole              = modkernel32.NewProc("FreeConsole")
+	procSetConsoleCtrlHandler    = modkernel32.NewProc("SetConsoleCtrlHandler")
+)
+
+const ctrlCEvent = 0
+
+// Suspend freezes every thread in pid via the undocumented but
+// long-stable NtSuspendProcess.
+func Suspend(pid int) error {
+	handle, err := syscall.OpenProcess(processSuspendResume, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("control: open pid %d for suspend: %w", pid, err)
+	}
+	defer syscall.CloseHandle(handle)
+
+	status, _, _ := procNtSuspendProcess.Call(uintptr(handle))
+	if status != 0 {
+		return fmt.Errorf("control: NtSuspendProcess(%d) failed with status %x", pid, status)
+	}
+	return nil
+}
+
+// Resume un-freezes a process previously suspended with Suspend.
+func Resume(pid int) error {
+	handle, err := syscall.OpenProcess(processSuspendResume, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("control: open pid %d for resume: %w", pid, err)
+	}
+	defer syscall.CloseHandle(handle)
+
+	status, _, _ := procNtResumeProcess.Call(uintptr(handle))
+	if status != 0 {
+		return fmt.Errorf("control: NtResumeProcess(%d) failed with status %x", pid, status)
+	}
+	return nil
+}
+
+// Signal approximates POSIX signal delivery on Windows: SIGKILL maps to
+// TerminateProcess, SIGINT maps to GenerateConsoleCtrlEvent (which only
+// works if the target shares our console), and anything else has no
+// faithful equivalent.
+func Signal(pid int, sig syscall.Signal) error {
+	switch sig {
+	case syscall.SIGKILL:
+		return terminateProcess(pid)
+	case syscall.SIGINT:
+		return generateCtrlC(pid)
+	default:
+		return fmt.Errorf("%w: signal %v", ErrUnsupported, sig)
+	}
+}
+
+func terminateProcess(pid int) error {
+	handle, err := syscall.OpenProcess(syscall.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("control: open pid %d for kill: %w", pid, err)
+	}
+	defer syscall.CloseHandle(handle)
+
+	if err := syscall.TerminateProcess(handle, 1); err != nil {
+		return fmt.Errorf("control: TerminateProcess(%d): %w", pid, err)
+	}
+	return nil
+}
+
+// generateCtrlC sends CTRL_C_EVENT to pid's console. GenerateConsoleCtrlEvent
+// only ever targets *our own* console's process group (a plain pid isn't a
+// valid group id), so we detach from whatever console we have and attach to
+// pid's instead, per the standard AttachConsole/GenerateConsoleCtrlEvent
+// dance. If pid has no console of its own (e.g. a service, or a GUI app),
+// AttachConsole fails and we report ErrUnsupported rather than risk
+// signalling the wrong process group.
+func generateCtrlC(pid int) error {
+	if ret, _, _ := procFreeConsole.Call(); ret == 0 {
+		return fmt.Errorf("%w: FreeConsole failed while preparing to signal pid %d", ErrUnsupported, pid)
+	}
+
+	ret, _, err := procAttachConsole.Call(uintptr(pid))
+	if ret == 0 {
+		return fmt.Errorf("%w: AttachConsole(%d): %v", ErrUnsupported, pid, err)
+	}
+	defer procFreeConsole.Call()
+
+	// Ignore the event in our own (now-attached) process so we don't act
+	// on the interrupt we're about to broadcast to the console group.
+	procSetConsoleCtrlHandler.Call(0, 1)
+
+	ret, _, err = procGenerateConsoleCtrlEvent.Call(uintptr(ctrlCEvent), 0)
+	if ret == 0 {
+		return fmt.Errorf("control: GenerateConsoleCtrlEvent(%d): %w", pid, err)
+	}
+	return nil
+}
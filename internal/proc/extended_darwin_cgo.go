@@ -0,0 +1,210 @@
+//go:build darwin && cgo
+
+package proc
+
+/*
+#include <libproc.h>
+#include <sys/proc_info.h>
+#include <sys/resource.h>
+#include <string.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/pranshuparmar/witr/pkg/model"
+)
+
+// ReadExtendedInfo assembles the additional process facts using libproc
+// directly instead of shelling out to ps/lsof/pgrep/launchctl. This avoids
+// locale-dependent text parsing, races against short-lived processes, and
+// the cost of spawning a handful of subprocesses per lookup, and finally
+// gives us real I/O counters via proc_pid_rusage instead of leaving them
+// zeroed.
+func ReadExtendedInfo(pid int) (model.MemoryInfo, model.IOStats, []string, int, uint64, []int, int, error) {
+	memInfo, threadCount, memErr := readDarwinMemoryLibproc(pid)
+	fdCount, fileDescs, fdErr := collectDarwinFDsLibproc(pid)
+	fdLimit := detectDarwinFileLimit()
+
+	// proc_pid_rusage is denied (EPERM) when inspecting a process owned by
+	// another user; degrade to zeroed I/O rather than failing the whole
+	// call, matching the nocgo fallback's leniency.
+	ioStats, ioErr := readDarwinIOStats(pid)
+	if ioErr != nil {
+		ioStats = model.IOStats{}
+	}
+
+	// A process can exit between proc_listpids and the per-candidate stat;
+	// treat that the same way the nocgo fallback does and just return no
+	// children rather than failing the call.
+	children, childErr := listDarwinChildrenLibproc(pid)
+	if childErr != nil {
+		children = nil
+	}
+
+	var err error
+	if memErr != nil && fdErr != nil {
+		err = errors.Join(memErr, fdErr)
+	}
+	return memInfo, ioStats, fileDescs, fdCount, fdLimit, children, threadCount, err
+}
+
+// readDarwinMemoryLibproc asks the kernel directly for RSS, VSZ and thread
+// count via PROC_PIDTASKINFO, replacing the "ps -o rss=,vsz=,thcount=" shell-out.
+func readDarwinMemoryLibproc(pid int) (model.MemoryInfo, int, error) {
+	var memInfo model.MemoryInfo
+
+	var info C.struct_proc_taskinfo
+	n := C.proc_pidinfo(C.int(pid), C.PROC_PIDTASKINFO, 0, unsafe.Pointer(&info), C.int(unsafe.Sizeof(info)))
+	if int(n) <= 0 {
+		return memInfo, 0, fmt.Errorf("proc_pidinfo(PROC_PIDTASKINFO, %d): %w", pid, syscall.Errno(syscallErrno()))
+	}
+
+	memInfo.RSS = uint64(info.pti_resident_size)
+	memInfo.RSSMB = float64(memInfo.RSS) / (1024 * 1024)
+	memInfo.VMS = uint64(info.pti_virtual_size)
+	memInfo.VMSMB = float64(memInfo.VMS) / (1024 * 1024)
+
+	return memInfo, int(info.pti_threadnum), nil
+}
+
+// collectDarwinFDsLibproc lists the target's open file descriptors via
+// PROC_PIDLISTFDS and classifies each one with proc_pidfdinfo, producing the
+// same "FD TYPE TARGET" summary lines summarizeLsofLine used to build from
+// lsof(8) output.
+func collectDarwinFDsLibproc(pid int) (int, []string, error) {
+	// Ask for the required buffer size first.
+	size := C.proc_pidinfo(C.int(pid), C.PROC_PIDLISTFDS, 0, nil, 0)
+	if int(size) <= 0 {
+		return 0, nil, fmt.Errorf("proc_pidinfo(PROC_PIDLISTFDS, %d): size query failed", pid)
+	}
+
+	count := int(size) / int(unsafe.Sizeof(C.struct_proc_fdinfo{}))
+	buf := make([]C.struct_proc_fdinfo, count)
+	n := C.proc_pidinfo(C.int(pid), C.PROC_PIDLISTFDS, 0, unsafe.Pointer(&buf[0]), size)
+	if int(n) <= 0 {
+		return 0, nil, fmt.Errorf("proc_pidinfo(PROC_PIDLISTFDS, %d): %d", pid, n)
+	}
+	actual := int(n) / int(unsafe.Sizeof(C.struct_proc_fdinfo{}))
+	buf = buf[:actual]
+
+	var samples []string
+	for _, fd := range buf {
+		if len(samples) >= 10 {
+			break
+		}
+		if sample := summarizeProcFD(pid, fd); sample != "" {
+			samples = append(samples, sample)
+		}
+	}
+
+	return actual, samples, nil
+}
+
+// summarizeProcFD classifies one descriptor via proc_pidfdinfo, mirroring
+// the "FD TYPE TARGET" shape summarizeLsofLine produces from lsof rows.
+func summarizeProcFD(pid int, fd C.struct_proc_fdinfo) string {
+	typ := "?"
+	target := ""
+
+	switch fd.proc_fdtype {
+	case C.PROX_FDTYPE_VNODE:
+		typ = "VNODE"
+		var vi C.struct_vnode_fdinfowithpath
+		n := C.proc_pidfdinfo(C.int(pid), fd.proc_fd, C.PROC_PIDFDVNODEPATHINFO, unsafe.Pointer(&vi), C.int(unsafe.Sizeof(vi)))
+		if int(n) > 0 {
+			target = C.GoString(&vi.pvip.vip_path[0])
+		}
+	case C.PROX_FDTYPE_SOCKET:
+		typ = "SOCKET"
+		var si C.struct_socket_fdinfo
+		n := C.proc_pidfdinfo(C.int(pid), fd.proc_fd, C.PROC_PIDFDSOCKETINFO, unsafe.Pointer(&si), C.int(unsafe.Sizeof(si)))
+		if int(n) > 0 {
+			target = fmt.Sprintf("family=%d type=%d", int(si.psi.soi_family), int(si.psi.soi_type))
+		}
+	case C.PROX_FDTYPE_PIPE:
+		typ = "PIPE"
+	case C.PROX_FDTYPE_KQUEUE:
+		typ = "KQUEUE"
+	default:
+		typ = fmt.Sprintf("TYPE%d", int(fd.proc_fdtype))
+	}
+
+	return fmt.Sprintf("%d %-6s %s", int(fd.proc_fd), typ, target)
+}
+
+// readDarwinIOStats pulls cumulative disk I/O byte counters via
+// proc_pid_rusage(RUSAGE_INFO_V4), which is available to unprivileged
+// callers inspecting their own or a same-user process and doesn't need the
+// special entitlements the older per-process I/O APIs require.
+func readDarwinIOStats(pid int) (model.IOStats, error) {
+	var stats model.IOStats
+
+	var ru C.struct_rusage_info_v4
+	ret := C.proc_pid_rusage(C.int(pid), C.RUSAGE_INFO_V4, (*C.rusage_info_t)(unsafe.Pointer(&ru)))
+	if ret != 0 {
+		return stats, fmt.Errorf("proc_pid_rusage(%d): %w", pid, syscall.Errno(syscallErrno()))
+	}
+
+	stats.ReadBytes = uint64(ru.ri_diskio_bytesread)
+	stats.WriteBytes = uint64(ru.ri_diskio_byteswritten)
+
+	return stats, nil
+}
+
+// listDarwinChildrenLibproc enumerates every PID via proc_listpids and keeps
+// the ones whose parent PID (read from each candidate's own BSD info at
+// enumeration time) matches pid. Reading ppid per-candidate rather than
+// re-querying the parent afterwards means a child isn't missed just because
+// its parent has since exited between the listpids call and inspection.
+func listDarwinChildrenLibproc(pid int) ([]int, error) {
+	size := C.proc_listpids(C.PROC_ALL_PIDS, 0, nil, 0)
+	if int(size) <= 0 {
+		return nil, fmt.Errorf("proc_listpids: size query failed")
+	}
+
+	buf := make([]C.pid_t, int(size)/int(unsafe.Sizeof(C.pid_t(0)))+1)
+	n := C.proc_listpids(C.PROC_ALL_PIDS, 0, unsafe.Pointer(&buf[0]), C.int(len(buf))*C.int(unsafe.Sizeof(C.pid_t(0))))
+	if int(n) <= 0 {
+		return nil, fmt.Errorf("proc_listpids: %d", n)
+	}
+	buf = buf[:int(n)/int(unsafe.Sizeof(C.pid_t(0)))]
+
+	var children []int
+	for _, candidate := range buf {
+		if int(candidate) == 0 || int(candidate) == pid {
+			continue
+		}
+		var bsd C.struct_proc_bsdinfo
+		rc := C.proc_pidinfo(candidate, C.PROC_PIDTBSDINFO, 0, unsafe.Pointer(&bsd), C.int(unsafe.Sizeof(bsd)))
+		if int(rc) <= 0 {
+			// Process likely exited between listpids and this call; skip it.
+			continue
+		}
+		if int(bsd.pbi_ppid) == pid {
+			children = append(children, int(candidate))
+		}
+	}
+
+	return children, nil
+}
+
+// syscallErrno surfaces the C errno set by the last failed libproc call so
+// callers can wrap it into a Go error.
+func syscallErrno() syscall.Errno {
+	return syscall.Errno(*C.__error())
+}
+
+// detectDarwinFileLimit reads the caller's RLIMIT_NOFILE soft cap directly
+// via getrlimit(2) instead of shelling out to "launchctl limit maxfiles".
+func detectDarwinFileLimit() uint64 {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return 0
+	}
+	return uint64(rlimit.Cur)
+}
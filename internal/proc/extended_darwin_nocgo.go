@@ -1,4 +1,4 @@
-//go:build darwin
+//go:build darwin && !cgo
 
 package proc
 
@@ -13,7 +13,12 @@ import (
 )
 
 // ReadExtendedInfo assembles the additional process facts.
-// Without /proc, we lean on native utilities (ps, lsof, pgrep, launchctl)
+//
+// This is the cgo-disabled fallback: without libproc available we lean on
+// native utilities (ps, lsof, pgrep, launchctl), which is slower and racier
+// against short-lived processes but doesn't need a C toolchain. See
+// extended_darwin_cgo.go for the libproc-backed implementation used by
+// default builds.
 func ReadExtendedInfo(pid int) (model.MemoryInfo, model.IOStats, []string, int, uint64, []int, int, error) {
 	memInfo, threadCount, memErr := readDarwinMemory(pid)
 	fdCount, fileDescs, fdErr := collectDarwinFDs(pid)
@@ -58,6 +58,19 @@ type rtlUserProcessParameters struct {
 	Environment            uintptr
 }
 
+// environmentSizeOffset is the offset of EnvironmentSize within
+// RTL_USER_PROCESS_PARAMETERS. It differs between architectures because the
+// struct is packed with pointer-sized fields ahead of it.
+const (
+	environmentSizeOffset64 = 0x3F0
+	environmentSizeOffset32 = 0x290
+
+	// maxEnvironmentBlockSize caps how much of the target's environment
+	// block we'll ever read, so a corrupt EnvironmentSize field can't make
+	// us allocate or copy an unbounded amount of memory.
+	maxEnvironmentBlockSize = 128 * 1024
+)
+
 type PROCESSENTRY32 struct {
 	Size            uint32
 	CntUsage        uint32
@@ -174,11 +187,69 @@ func getFullProcessInfo(handle syscall.Handle, pid int, info *Win32ProcessInfo)
 	info.Cwd = readUnicodeString(handle, params.CurrentDirectoryPath)
 	info.CommandLine = readUnicodeString(handle, params.CommandLine)
 	info.Exe = readUnicodeString(handle, params.ImagePathName)
-	info.Env = []string{}
+	info.Env = readProcessEnvironment(handle, pebPtr, params.Environment)
 
 	return nil
 }
 
+// readProcessEnvironment reads the target process's environment block from
+// the address stored in RTL_USER_PROCESS_PARAMETERS.Environment. It returns
+// an empty slice if any step fails, since a missing environment shouldn't
+// prevent the rest of getFullProcessInfo from succeeding.
+func readProcessEnvironment(handle syscall.Handle, paramsAddr, envAddr uintptr) []string {
+	if envAddr == 0 {
+		return []string{}
+	}
+
+	envSizeOffset := uintptr(environmentSizeOffset64)
+	if unsafe.Sizeof(uintptr(0)) == 4 {
+		envSizeOffset = environmentSizeOffset32
+	}
+
+	var envSize uint32
+	if !readProcessMemory(handle, paramsAddr+envSizeOffset, unsafe.Pointer(&envSize), unsafe.Sizeof(envSize)) {
+		return []string{}
+	}
+	if envSize == 0 || uintptr(envSize) > maxEnvironmentBlockSize {
+		envSize = maxEnvironmentBlockSize
+	}
+
+	buf := make([]uint16, envSize/2)
+	if len(buf) == 0 {
+		return []string{}
+	}
+	if !readProcessMemory(handle, envAddr, unsafe.Pointer(&buf[0]), uintptr(len(buf)*2)) {
+		return []string{}
+	}
+
+	return parseEnvironmentBlock(buf)
+}
+
+// parseEnvironmentBlock splits a raw Windows environment block into
+// KEY=VALUE strings. The block is a sequence of NUL-terminated UTF-16
+// strings terminated by an extra NUL (i.e. an empty string).
+func parseEnvironmentBlock(buf []uint16) []string {
+	var env []string
+	start := 0
+	for i, c := range buf {
+		if c != 0 {
+			continue
+		}
+		if i == start {
+			// Empty string marks the end of the block.
+			break
+		}
+		if s := syscall.UTF16ToString(buf[start:i]); s != "" {
+			env = append(env, s)
+		}
+		start = i + 1
+	}
+	if env == nil {
+		env = []string{}
+	}
+	return env
+}
+
 func readProcessMemory(handle syscall.Handle, addr uintptr, dest unsafe.Pointer, size uintptr) bool {
 	var read uint32
 	ret, _, _ := procReadProcessMem.Call(
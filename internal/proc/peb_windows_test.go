@@ -0,0 +1,61 @@
+//go:build windows
+
+package proc
+
+import (
+	"reflect"
+	"syscall"
+	"testing"
+)
+
+func utf16Block(parts ...string) []uint16 {
+	var buf []uint16
+	for _, p := range parts {
+		buf = append(buf, syscall.StringToUTF16(p)...)
+	}
+	buf = append(buf, 0)
+	return buf
+}
+
+func TestParseEnvironmentBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  []uint16
+		want []string
+	}{
+		{
+			name: "multiple entries",
+			buf:  utf16Block("PATH=/usr/bin", "HOME=/root"),
+			want: []string{"PATH=/usr/bin", "HOME=/root"},
+		},
+		{
+			name: "single entry",
+			buf:  utf16Block("FOO=bar"),
+			want: []string{"FOO=bar"},
+		},
+		{
+			name: "empty block",
+			buf:  []uint16{0, 0},
+			want: []string{},
+		},
+		{
+			name: "no data",
+			buf:  nil,
+			want: []string{},
+		},
+		{
+			name: "single entry with explicit double-NUL terminator",
+			buf:  []uint16{'A', '=', '1', 0, 0, 0},
+			want: []string{"A=1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseEnvironmentBlock(tt.buf)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEnvironmentBlock(%v) = %v, want %v", tt.buf, got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/pranshuparmar/witr/internal/proc/container"
 	"github.com/pranshuparmar/witr/pkg/model"
 )
 
@@ -25,7 +26,7 @@ func ReadProcess(pid int) (model.Process, error) {
 
 	ports, addrs := GetListeningPortsForPID(pid)
 	serviceName := detectWindowsServiceSource(pid)
-	container := detectContainer(info.CommandLine)
+	containerInfo := detectContainer(info.CommandLine)
 	gitRepo, gitBranch := detectGitInfo(info.Cwd)
 
 	return model.Process{
@@ -45,7 +46,7 @@ func ReadProcess(pid int) (model.Process, error) {
 		Forked:         "unknown",
 		Env:            info.Env,
 		Service:        serviceName,
-		Container:      container,
+		Container:      containerInfo,
 		ExeDeleted:     isWindowsBinaryDeleted(info.Exe),
 	}, nil
 }
@@ -71,54 +72,55 @@ func detectWindowsServiceSource(pid int) string {
 	return strings.TrimSpace(string(out))
 }
 
-func detectContainer(cmdline string) string {
+// dockerNamedPipe is where the Docker Engine API listens on Windows when
+// running in Windows container mode (as opposed to the Linux-container /
+// WSL2 backend, which is reached over a UNIX socket inside the VM instead).
+const dockerNamedPipe = `\\.\pipe\docker_engine`
+
+// detectContainer inspects cmdline for signs the target is a container
+// runtime or is running under one, returning the same container.Info shape
+// the Linux cgroup-based detector (container.Detect) produces so callers
+// don't need to special-case platforms.
+func detectContainer(cmdline string) container.Info {
 	if cmdline == "" {
-		return ""
+		return detectContainerViaNamedPipe()
 	}
 	lowerCmd := strings.ToLower(cmdline)
 
 	switch {
 	case strings.Contains(lowerCmd, "docker"):
-		if name := extractFlagValue(cmdline, "--name"); name != "" {
-			return "docker: " + name
-		}
-		return "docker"
+		return container.Info{Runtime: "docker", ContainerName: extractFlagValue(cmdline, "--name")}
 	case strings.Contains(lowerCmd, "podman"):
-		if name := extractFlagValue(cmdline, "--name"); name != "" {
-			return "podman: " + name
-		}
-		return "podman"
+		return container.Info{Runtime: "podman", ContainerName: extractFlagValue(cmdline, "--name")}
 	case strings.Contains(lowerCmd, "minikube"):
-		if profile := extractFlagValue(cmdline, "-p", "--profile"); profile != "" {
-			return "k8s: " + profile
-		}
-		return "kubernetes"
+		return container.Info{Runtime: "kubernetes", ContainerName: extractFlagValue(cmdline, "-p", "--profile")}
 	case strings.Contains(lowerCmd, "kind"):
-		if name := extractFlagValue(cmdline, "--name"); name != "" {
-			return "k8s: " + name
-		}
-		return "kubernetes"
+		return container.Info{Runtime: "kubernetes", ContainerName: extractFlagValue(cmdline, "--name")}
 	case strings.Contains(lowerCmd, "kubepods"):
+		info := container.Info{Runtime: "kubernetes"}
 		if id := findLongHexID(cmdline); id != "" {
-			if name := resolveContainerName(id, "crictl"); name != "" {
-				return "k8s: " + name
-			}
-			return "k8s (" + id[:12] + ")"
+			info.ContainerID = id
+			info.ContainerName = resolveContainerName(id, "crictl")
 		}
-		return "kubernetes"
+		return info
 	case strings.Contains(lowerCmd, "nerdctl"):
-		if name := extractFlagValue(cmdline, "--name"); name != "" {
-			return "containerd: " + name
-		}
-		return "containerd"
+		return container.Info{Runtime: "containerd", ContainerName: extractFlagValue(cmdline, "--name")}
 	case strings.Contains(lowerCmd, "containerd"):
-		if name := extractFlagValue(cmdline, "--name"); name != "" {
-			return "containerd: " + name
-		}
-		return "containerd"
+		return container.Info{Runtime: "containerd", ContainerName: extractFlagValue(cmdline, "--name")}
 	}
 
-	return ""
+	return detectContainerViaNamedPipe()
+}
+
+// detectContainerViaNamedPipe checks whether the Docker Engine API is
+// reachable over its Windows named pipe, as a fallback for containers
+// launched indirectly (e.g. by a supervisor) whose command line doesn't
+// mention docker/podman/k8s at all.
+func detectContainerViaNamedPipe() container.Info {
+	if _, err := os.Stat(dockerNamedPipe); err != nil {
+		return container.Info{}
+	}
+	return container.Info{Runtime: "docker"}
 }
 
 func detectGitInfo(cwd string) (string, string) {
@@ -0,0 +1,114 @@
+// Package sampler computes rate-based process metrics — CPU%, I/O
+// throughput, and context-switch rate — by taking two snapshots of a PID
+// separated by a caller-supplied interval and diffing them.
+package sampler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// ErrPIDReused is returned by Observe when the target PID's reported start
+// time changes between the two snapshots, meaning the kernel recycled the
+// PID for an unrelated process mid-sample.
+var ErrPIDReused = errors.New("sampler: pid was reused between snapshots")
+
+// Sample holds the rate-based metrics computed from two snapshots. It's
+// meant to be surfaced to callers as model.Result.Live when live mode is
+// requested, so output.ToJSON picks it up automatically.
+type Sample struct {
+	Interval              time.Duration
+	CPUPercent            float64
+	ReadBytesPerSec       float64
+	WriteBytesPerSec      float64
+	ContextSwitchesPerSec float64
+}
+
+// snapshot captures a single point-in-time reading of a process's
+// cumulative counters. Platform-specific files populate this via
+// takeSnapshot.
+type snapshot struct {
+	timestamp   time.Time
+	startTime   time.Time
+	cpuTime     time.Duration
+	readBytes   uint64
+	writeBytes  uint64
+	ctxSwitches uint64
+}
+
+// Sampler observes PID metrics over time. It holds no state itself; it
+// exists so callers have somewhere to hang future configuration (e.g. a
+// fake clock in tests) without changing Observe's signature.
+type Sampler struct{}
+
+// New returns a ready-to-use Sampler.
+func New() *Sampler {
+	return &Sampler{}
+}
+
+// Observe takes a snapshot of pid, waits for interval (or until ctx is
+// done), takes a second snapshot, and returns the rates between them.
+func (s *Sampler) Observe(ctx context.Context, pid int, interval time.Duration) (Sample, error) {
+	first, err := takeSnapshot(pid)
+	if err != nil {
+		return Sample{}, fmt.Errorf("sampler: first snapshot of pid %d: %w", pid, err)
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return Sample{}, ctx.Err()
+	case <-timer.C:
+	}
+
+	second, err := takeSnapshot(pid)
+	if err != nil {
+		return Sample{}, fmt.Errorf("sampler: second snapshot of pid %d: %w", pid, err)
+	}
+
+	if !first.startTime.IsZero() && !second.startTime.IsZero() && !first.startTime.Equal(second.startTime) {
+		return Sample{}, ErrPIDReused
+	}
+
+	return diff(first, second), nil
+}
+
+// diff turns two snapshots into rate-based metrics, normalizing CPU time by
+// wall-clock elapsed and the number of logical CPUs so a process fully
+// saturating every core reads as 100%, rather than top(1)/ps(1)'s N*100%
+// scale.
+func diff(first, second snapshot) Sample {
+	elapsed := second.timestamp.Sub(first.timestamp)
+	if elapsed <= 0 {
+		return Sample{Interval: elapsed}
+	}
+	elapsedSec := elapsed.Seconds()
+
+	cpuDelta := second.cpuTime - first.cpuTime
+	cpuPercent := (cpuDelta.Seconds() / elapsedSec) * 100 / float64(runtime.NumCPU())
+
+	var readRate, writeRate float64
+	if second.readBytes >= first.readBytes {
+		readRate = float64(second.readBytes-first.readBytes) / elapsedSec
+	}
+	if second.writeBytes >= first.writeBytes {
+		writeRate = float64(second.writeBytes-first.writeBytes) / elapsedSec
+	}
+
+	var ctxRate float64
+	if second.ctxSwitches >= first.ctxSwitches {
+		ctxRate = float64(second.ctxSwitches-first.ctxSwitches) / elapsedSec
+	}
+
+	return Sample{
+		Interval:              elapsed,
+		CPUPercent:            cpuPercent,
+		ReadBytesPerSec:       readRate,
+		WriteBytesPerSec:      writeRate,
+		ContextSwitchesPerSec: ctxRate,
+	}
+}
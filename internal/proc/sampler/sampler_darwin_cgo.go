@@ -0,0 +1,37 @@
+//go:build darwin && cgo
+
+package sampler
+
+/*
+#include <libproc.h>
+#include <sys/resource.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+// takeSnapshot uses proc_pid_rusage(RUSAGE_INFO_V4), the same call the
+// libproc-backed extended-info path uses, since it conveniently bundles CPU
+// time, I/O bytes, and process start time in a single unprivileged syscall.
+func takeSnapshot(pid int) (snapshot, error) {
+	var ru C.struct_rusage_info_v4
+	ret := C.proc_pid_rusage(C.int(pid), C.RUSAGE_INFO_V4, (*C.rusage_info_t)(unsafe.Pointer(&ru)))
+	if ret != 0 {
+		return snapshot{}, fmt.Errorf("proc_pid_rusage(%d): errno %d", pid, *C.__error())
+	}
+
+	cpuTime := time.Duration(ru.ri_user_time+ru.ri_system_time) * time.Nanosecond
+	startTime := time.Unix(0, int64(ru.ri_proc_start_abstime))
+
+	return snapshot{
+		timestamp:  time.Now(),
+		startTime:  startTime,
+		cpuTime:    cpuTime,
+		readBytes:  uint64(ru.ri_diskio_bytesread),
+		writeBytes: uint64(ru.ri_diskio_byteswritten),
+	}, nil
+}
@@ -0,0 +1,14 @@
+//go:build darwin && !cgo
+
+package sampler
+
+import "errors"
+
+// ErrUnsupported is returned when live sampling isn't available in this
+// build. On Darwin, per-process CPU/IO counters require proc_pid_rusage via
+// cgo; see sampler_darwin_cgo.go for the real implementation.
+var ErrUnsupported = errors.New("sampler: live sampling requires a cgo-enabled build on darwin")
+
+func takeSnapshot(pid int) (snapshot, error) {
+	return snapshot{}, ErrUnsupported
+}
@@ -0,0 +1,56 @@
+//go:build linux
+
+package sampler
+
+import "testing"
+
+func TestParseProcStat(t *testing.T) {
+	tests := []struct {
+		name                          string
+		line                          string
+		wantUtime, wantStime, wantStart uint64
+		wantErr                       bool
+	}{
+		{
+			name: "well-formed line",
+			// Fields after comm start at "state"; utime/stime/starttime are
+			// fields[11]/fields[12]/fields[19] of that slice.
+			line:      "1234 (bash) S 1 1234 1234 0 -1 4194304 100 0 0 0 11 22 0 0 20 0 1 0 33 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0",
+			wantUtime: 11, wantStime: 22, wantStart: 33,
+		},
+		{
+			name:      "comm contains spaces and parens",
+			line:      "1234 (my (weird) process) S 1 1234 1234 0 -1 4194304 100 0 0 0 11 22 0 0 20 0 1 0 33 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0",
+			wantUtime: 11, wantStime: 22, wantStart: 33,
+		},
+		{
+			name:    "no closing paren",
+			line:    "1234 bash S 1",
+			wantErr: true,
+		},
+		{
+			name:    "too few fields after comm",
+			line:    "1234 (bash) S 1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utime, stime, start, err := parseProcStat(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseProcStat(%q) = nil error, want error", tt.line)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseProcStat(%q) returned unexpected error: %v", tt.line, err)
+			}
+			if utime != tt.wantUtime || stime != tt.wantStime || start != tt.wantStart {
+				t.Errorf("parseProcStat(%q) = (%d, %d, %d), want (%d, %d, %d)",
+					tt.line, utime, stime, start, tt.wantUtime, tt.wantStime, tt.wantStart)
+			}
+		})
+	}
+}
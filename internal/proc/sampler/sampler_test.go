@@ -0,0 +1,65 @@
+package sampler
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	start := time.Now()
+
+	t.Run("normalizes CPU% by NumCPU so full saturation reads 100%", func(t *testing.T) {
+		first := snapshot{timestamp: start, cpuTime: 0}
+		second := snapshot{
+			timestamp: start.Add(time.Second),
+			cpuTime:   time.Duration(runtime.NumCPU()) * time.Second,
+		}
+
+		got := diff(first, second)
+		if got.CPUPercent < 99.99 || got.CPUPercent > 100.01 {
+			t.Errorf("CPUPercent = %v, want ~100", got.CPUPercent)
+		}
+	})
+
+	t.Run("computes read/write/ctx rates over the elapsed interval", func(t *testing.T) {
+		first := snapshot{timestamp: start, readBytes: 1000, writeBytes: 2000, ctxSwitches: 10}
+		second := snapshot{
+			timestamp:   start.Add(2 * time.Second),
+			readBytes:   3000,
+			writeBytes:  2500,
+			ctxSwitches: 30,
+		}
+
+		got := diff(first, second)
+		if got.ReadBytesPerSec != 1000 {
+			t.Errorf("ReadBytesPerSec = %v, want 1000", got.ReadBytesPerSec)
+		}
+		if got.WriteBytesPerSec != 250 {
+			t.Errorf("WriteBytesPerSec = %v, want 250", got.WriteBytesPerSec)
+		}
+		if got.ContextSwitchesPerSec != 10 {
+			t.Errorf("ContextSwitchesPerSec = %v, want 10", got.ContextSwitchesPerSec)
+		}
+	})
+
+	t.Run("counters that went backwards (pid reuse) clamp rates to zero instead of going negative", func(t *testing.T) {
+		first := snapshot{timestamp: start, readBytes: 5000, writeBytes: 5000, ctxSwitches: 100}
+		second := snapshot{timestamp: start.Add(time.Second), readBytes: 100, writeBytes: 100, ctxSwitches: 5}
+
+		got := diff(first, second)
+		if got.ReadBytesPerSec != 0 || got.WriteBytesPerSec != 0 || got.ContextSwitchesPerSec != 0 {
+			t.Errorf("diff() = %+v, want all rates zeroed", got)
+		}
+	})
+
+	t.Run("non-positive elapsed time returns a zeroed sample", func(t *testing.T) {
+		first := snapshot{timestamp: start}
+		second := snapshot{timestamp: start}
+
+		got := diff(first, second)
+		if got != (Sample{Interval: 0}) {
+			t.Errorf("diff() = %+v, want zero-value Sample with Interval 0", got)
+		}
+	})
+}